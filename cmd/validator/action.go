@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Gnomon-iterative/github-team-validator/internal/auth"
+	"github.com/Gnomon-iterative/github-team-validator/internal/policy"
+	"github.com/Gnomon-iterative/github-team-validator/internal/reporter"
+	"github.com/Gnomon-iterative/github-team-validator/internal/teams"
+)
+
+// codeownersPaths are tried, in order, to find a repository's CODEOWNERS
+// file, matching where GitHub itself looks for one.
+var codeownersPaths = []string{"CODEOWNERS", ".github/CODEOWNERS", "docs/CODEOWNERS"}
+
+// prEvent is the subset of a pull_request event payload this command needs.
+type prEvent struct {
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+	PullRequest struct {
+		Number int `json:"number"`
+		Head   struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	} `json:"pull_request"`
+}
+
+func newActionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "action [changed-files...]",
+		Short: "Validate the files changed in a pull request event, reporting via the Checks API and a PR comment",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			policyFile, _ := cmd.Flags().GetString("policy-file")
+			return runAction(args, policyFile)
+		},
+	}
+}
+
+func runAction(changedFiles []string, policyFile string) error {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	eventPath := os.Getenv("GITHUB_EVENT_PATH")
+	prAuthor := os.Getenv("GITHUB_ACTOR")
+	orgName := os.Getenv("GITHUB_REPOSITORY_OWNER")
+	if eventPath == "" || orgName == "" {
+		return fmt.Errorf("GITHUB_EVENT_PATH and GITHUB_REPOSITORY_OWNER must be set")
+	}
+
+	eventData, err := os.ReadFile(eventPath)
+	if err != nil {
+		return fmt.Errorf("reading event payload: %w", err)
+	}
+	var event prEvent
+	if err := json.Unmarshal(eventData, &event); err != nil {
+		return fmt.Errorf("parsing event payload: %w", err)
+	}
+
+	engine, pol, err := policy.LoadEngine(policyFile)
+	if err != nil {
+		return fmt.Errorf("loading policy file: %w", err)
+	}
+
+	ctx := context.Background()
+	authCfg, err := auth.ConfigFromEnv("GITHUB_TOKEN")
+	if err != nil {
+		return fmt.Errorf("resolving authentication: %w", err)
+	}
+	client, cache, err := auth.NewClient(ctx, authCfg)
+	if err != nil {
+		return fmt.Errorf("creating GitHub client: %w", err)
+	}
+
+	check, err := reporter.Start(ctx, client, event.Repository.Owner.Login, event.Repository.Name, event.PullRequest.Head.SHA)
+	if err != nil {
+		return fmt.Errorf("creating check run: %w", err)
+	}
+
+	resolver := teams.NewResolver(client, orgName, readLocalCodeowners())
+
+	var violations []policy.Violation
+	filesEvaluated := 0
+	for _, filePath := range changedFiles {
+		if !isYAML(filePath) {
+			continue
+		}
+
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			violations = append(violations, policy.Violation{File: filePath, Message: fmt.Sprintf("reading file: %v", err)})
+			continue
+		}
+		filesEvaluated++
+
+		obj, err := policy.ParseManifest(data)
+		if err != nil {
+			violations = append(violations, policy.Violation{File: filePath, Message: err.Error()})
+			continue
+		}
+
+		fileViolations := engine.Evaluate(filePath, obj)
+		if lines, err := policy.NewLineIndex(data); err == nil {
+			policy.AttachLines(fileViolations, lines)
+		}
+		violations = append(violations, fileViolations...)
+
+		annotations := obj.GetAnnotations()
+		candidateTeams := resolver.TeamsForPath(filePath, teams.ParseTeamList(annotations["team"]))
+		if len(candidateTeams) > 0 {
+			var permitted []string
+			if pol != nil {
+				permitted = pol.PermittedTeamsFor(filePath)
+			}
+			member, err := resolver.IsMember(ctx, prAuthor, candidateTeams, permitted)
+			if err != nil {
+				violations = append(violations, policy.Violation{File: filePath, Message: err.Error()})
+			} else if !member {
+				violations = append(violations, policy.Violation{
+					File:    filePath,
+					Message: fmt.Sprintf("user %s is not an active member of any permitted team for this namespace: %v", prAuthor, candidateTeams),
+				})
+			}
+		}
+
+		if sourceRepo := annotations["source-code"]; sourceRepo != "" {
+			if err := checkRepositoryStatus(ctx, client, sourceRepo); err != nil {
+				violations = append(violations, policy.Violation{File: filePath, Message: err.Error()})
+			}
+		}
+	}
+
+	if err := check.Finish(ctx, violations, filesEvaluated); err != nil {
+		log.Printf("Error updating check run: %v", err)
+	}
+
+	metrics := cache.Metrics()
+	message := buildSummary(violations, &metrics)
+	if err := commentOnPR(ctx, client, event.Repository.Owner.Login, event.Repository.Name, event.PullRequest.Number, message); err != nil {
+		log.Printf("Error posting PR comment: %v", err)
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("validation failed with %d violation(s)", len(violations))
+	}
+
+	log.Printf("All validations passed!")
+	return nil
+}
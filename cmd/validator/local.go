@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Gnomon-iterative/github-team-validator/internal/policy"
+	"github.com/Gnomon-iterative/github-team-validator/internal/teams"
+)
+
+func newLocalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "local [files...]",
+		Short: "Validate namespace manifests in the working tree, without posting to a pull request",
+		Long: "local runs the same policy engine the action uses, but against a developer's\n" +
+			"working tree instead of a pull request: it's meant for a pre-commit hook or a\n" +
+			"`make lint` target. If no files are given, changed files are discovered with\n" +
+			"`git diff`.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			base, _ := cmd.Flags().GetString("base")
+			staged, _ := cmd.Flags().GetBool("staged")
+			skipRemote, _ := cmd.Flags().GetBool("skip-remote")
+			as, _ := cmd.Flags().GetString("as")
+			cacheDir, _ := cmd.Flags().GetString("cache-dir")
+			policyFile, _ := cmd.Flags().GetString("policy-file")
+			return runLocal(localOptions{
+				files:      args,
+				base:       base,
+				staged:     staged,
+				skipRemote: skipRemote,
+				as:         as,
+				cacheDir:   cacheDir,
+				policyFile: policyFile,
+			})
+		},
+	}
+
+	cmd.Flags().String("base", "", `diff against "<base>..HEAD" to find changed files (ignored with --staged)`)
+	cmd.Flags().Bool("staged", false, "validate staged files instead of diffing against --base, for use as a pre-commit hook")
+	cmd.Flags().Bool("skip-remote", false, "skip team-membership and source-repository checks, which require a GitHub token")
+	cmd.Flags().String("as", os.Getenv("GITHUB_ACTOR"), "GitHub username to check team membership for")
+	cmd.Flags().String("cache-dir", "", "directory to cache repository-existence lookups in (defaults to ~/.cache/github-team-validator)")
+	return cmd
+}
+
+type localOptions struct {
+	files      []string
+	base       string
+	staged     bool
+	skipRemote bool
+	as         string
+	cacheDir   string
+	policyFile string
+}
+
+func runLocal(opts localOptions) error {
+	files := opts.files
+	if len(files) == 0 {
+		discovered, err := discoverChangedFiles(opts.base, opts.staged)
+		if err != nil {
+			return err
+		}
+		files = discovered
+	}
+
+	engine, pol, err := policy.LoadEngine(opts.policyFile)
+	if err != nil {
+		return fmt.Errorf("loading policy file: %w", err)
+	}
+
+	ctx := context.Background()
+	var (
+		resolver *teams.Resolver
+		repos    *repoCache
+	)
+	if !opts.skipRemote {
+		remote, err := newRemoteChecker(ctx, opts.cacheDir)
+		if err != nil {
+			return fmt.Errorf("setting up remote checks (use --skip-remote to validate without a token): %w", err)
+		}
+		resolver = remote.resolver
+		repos = remote.repos
+	}
+
+	var violations []policy.Violation
+	filesEvaluated := 0
+
+	for _, filePath := range files {
+		if !isYAML(filePath) {
+			continue
+		}
+
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			violations = append(violations, policy.Violation{File: filePath, Message: fmt.Sprintf("reading file: %v", err)})
+			continue
+		}
+		filesEvaluated++
+
+		obj, err := policy.ParseManifest(data)
+		if err != nil {
+			violations = append(violations, policy.Violation{File: filePath, Message: err.Error()})
+			continue
+		}
+
+		fileViolations := engine.Evaluate(filePath, obj)
+		if lines, err := policy.NewLineIndex(data); err == nil {
+			policy.AttachLines(fileViolations, lines)
+		}
+		violations = append(violations, fileViolations...)
+
+		if opts.skipRemote {
+			continue
+		}
+
+		annotations := obj.GetAnnotations()
+		candidateTeams := resolver.TeamsForPath(filePath, teams.ParseTeamList(annotations["team"]))
+		if len(candidateTeams) > 0 {
+			var permitted []string
+			if pol != nil {
+				permitted = pol.PermittedTeamsFor(filePath)
+			}
+			member, err := resolver.IsMember(ctx, opts.as, candidateTeams, permitted)
+			if err != nil {
+				violations = append(violations, policy.Violation{File: filePath, Message: err.Error()})
+			} else if !member {
+				violations = append(violations, policy.Violation{
+					File:    filePath,
+					Message: fmt.Sprintf("user %s is not an active member of any permitted team for this namespace: %v", opts.as, candidateTeams),
+				})
+			}
+		}
+
+		if sourceRepo := annotations["source-code"]; sourceRepo != "" {
+			if err := repos.checkRepositoryStatus(ctx, sourceRepo); err != nil {
+				violations = append(violations, policy.Violation{File: filePath, Message: err.Error()})
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		fmt.Printf("Validation failed with %d violation(s):\n", len(violations))
+		for _, v := range violations {
+			fmt.Printf("  - %s\n", v)
+		}
+		return fmt.Errorf("%d file(s) violated policy", countFiles(violations))
+	}
+
+	fmt.Printf("All validations passed (%d file(s) checked)\n", filesEvaluated)
+	return nil
+}
+
+// discoverChangedFiles shells out to git to find files worth validating,
+// mirroring the file sets the "action" subcommand and a pre-commit hook
+// would each naturally see.
+func discoverChangedFiles(base string, staged bool) ([]string, error) {
+	args := []string{"diff", "--name-only", "--diff-filter=ACMR"}
+	if staged {
+		args = append(args, "--cached")
+	} else {
+		if base == "" {
+			return nil, fmt.Errorf("either --base or --staged is required to discover changed files")
+		}
+		args = append(args, base+"..HEAD")
+	}
+
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("running git diff: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+func countFiles(violations []policy.Violation) int {
+	files := make(map[string]bool, len(violations))
+	for _, v := range violations {
+		files[v.File] = true
+	}
+	return len(files)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+
+	"github.com/Gnomon-iterative/github-team-validator/internal/auth"
+	"github.com/Gnomon-iterative/github-team-validator/internal/teams"
+)
+
+// remoteChecker bundles the GitHub client, team resolver, and on-disk
+// repository-status cache local mode needs for the (optional) remote
+// checks, so callers only have to construct one thing.
+type remoteChecker struct {
+	resolver *teams.Resolver
+	repos    *repoCache
+}
+
+func newRemoteChecker(ctx context.Context, cacheDir string) (*remoteChecker, error) {
+	authCfg, err := auth.ConfigFromEnv("GITHUB_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("resolving authentication: %w", err)
+	}
+	client, _, err := auth.NewClient(ctx, authCfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating GitHub client: %w", err)
+	}
+
+	repos, err := newRepoCache(client, cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteChecker{
+		resolver: teams.NewResolver(client, os.Getenv("GITHUB_REPOSITORY_OWNER"), readLocalCodeowners()),
+		repos:    repos,
+	}, nil
+}
+
+// repoCache persists the result of source-code repository-existence checks
+// to disk, so running local validation repeatedly against the same
+// namespace files (the common pre-commit/edit-save loop) doesn't re-fetch
+// the same repository on every run.
+type repoCache struct {
+	client  *github.Client
+	path    string
+	entries map[string]repoCacheEntry
+}
+
+type repoCacheEntry struct {
+	Exists  bool `json:"exists"`
+	Private bool `json:"private"`
+}
+
+// newRepoCache opens (or initializes) the on-disk cache under dir, which
+// defaults to ~/.cache/github-team-validator.
+func newRepoCache(client *github.Client, dir string) (*repoCache, error) {
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("resolving home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".cache", "github-team-validator")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating cache dir %s: %w", dir, err)
+	}
+
+	c := &repoCache{client: client, path: filepath.Join(dir, "repos.json"), entries: map[string]repoCacheEntry{}}
+	if data, err := os.ReadFile(c.path); err == nil {
+		_ = json.Unmarshal(data, &c.entries)
+	}
+	return c, nil
+}
+
+// checkRepositoryStatus verifies that sourceRepo points at an existing,
+// public repository, consulting (and updating) the on-disk cache instead of
+// hitting the API again for a repository already seen this cache's lifetime.
+func (c *repoCache) checkRepositoryStatus(ctx context.Context, sourceRepo string) error {
+	parts := strings.Split(strings.TrimPrefix(sourceRepo, "https://github.com/"), "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid source-code URL format: %s", sourceRepo)
+	}
+	key := parts[0] + "/" + parts[1]
+
+	entry, ok := c.entries[key]
+	if !ok {
+		repo, resp, err := c.client.Repositories.Get(ctx, parts[0], parts[1])
+		if err != nil {
+			if resp != nil && resp.StatusCode == 404 {
+				entry = repoCacheEntry{Exists: false}
+				c.save(key, entry)
+			} else {
+				return fmt.Errorf("source code repository does not exist or is not accessible: %w", err)
+			}
+		} else {
+			entry = repoCacheEntry{Exists: true, Private: repo.GetPrivate()}
+			c.save(key, entry)
+		}
+	}
+
+	if !entry.Exists {
+		return fmt.Errorf("source code repository does not exist or is not accessible: %s", key)
+	}
+	if entry.Private {
+		return fmt.Errorf("source code repository must be public")
+	}
+	return nil
+}
+
+func (c *repoCache) save(key string, entry repoCacheEntry) {
+	c.entries[key] = entry
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path, data, 0o644)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+
+	"github.com/Gnomon-iterative/github-team-validator/internal/ghcache"
+	"github.com/Gnomon-iterative/github-team-validator/internal/policy"
+)
+
+// isYAML reports whether filePath looks like a namespace manifest worth
+// evaluating.
+func isYAML(filePath string) bool {
+	return strings.HasSuffix(filePath, ".yaml") || strings.HasSuffix(filePath, ".yml")
+}
+
+// readLocalCodeowners looks for a CODEOWNERS file in the checkout rather
+// than fetching one over the API, since both the action and local commands
+// already run against a full working tree.
+func readLocalCodeowners() []byte {
+	for _, path := range codeownersPaths {
+		if data, err := os.ReadFile(path); err == nil {
+			return data
+		}
+	}
+	return nil
+}
+
+// checkRepositoryStatus verifies that the source-code annotation points at
+// an existing, public repository.
+func checkRepositoryStatus(ctx context.Context, client *github.Client, sourceRepo string) error {
+	parts := strings.Split(strings.TrimPrefix(sourceRepo, "https://github.com/"), "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid source-code URL format: %s", sourceRepo)
+	}
+
+	repo, _, err := client.Repositories.Get(ctx, parts[0], parts[1])
+	if err != nil {
+		return fmt.Errorf("source code repository does not exist or is not accessible: %w", err)
+	}
+
+	if repo.GetPrivate() {
+		return fmt.Errorf("source code repository must be public")
+	}
+
+	return nil
+}
+
+// commentOnPR posts a summary comment on the pull request
+// under review.
+func commentOnPR(ctx context.Context, client *github.Client, owner, repo string, prNumber int, message string) error {
+	_, _, err := client.Issues.CreateComment(ctx, owner, repo, prNumber, &github.IssueComment{
+		Body: &message,
+	})
+	return err
+}
+
+// buildSummary renders the violations found, plus cache metrics, into a
+// single PR-comment body.
+func buildSummary(violations []policy.Violation, metrics *ghcache.Metrics) string {
+	var b strings.Builder
+
+	if len(violations) == 0 {
+		b.WriteString("✅ All validations passed!\n")
+	} else {
+		fmt.Fprintf(&b, "❌ Validation failed with %d violation(s):\n\n", len(violations))
+		for _, v := range violations {
+			fmt.Fprintf(&b, "- %s\n", v)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n<sub>GitHub API calls: %d made, %d cache hits, %d not-modified, %d retries</sub>\n",
+		metrics.CallsMade, metrics.CacheHits, metrics.NotModified, metrics.Retries)
+
+	return b.String()
+}
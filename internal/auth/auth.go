@@ -0,0 +1,142 @@
+// Package auth builds authenticated *github.Client instances from one of
+// several credential sources, so the validator can run against orgs that
+// prefer a GitHub App installation or Actions OIDC federation over issuing
+// personal access tokens.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-github/v57/github"
+	"golang.org/x/oauth2"
+
+	"github.com/Gnomon-iterative/github-team-validator/internal/ghcache"
+)
+
+// Mode selects how a GitHub client authenticates.
+type Mode string
+
+const (
+	// ModeToken authenticates with a static personal access token.
+	ModeToken Mode = "token"
+	// ModeApp authenticates as a GitHub App installation, minting
+	// short-lived installation tokens from an app private key.
+	ModeApp Mode = "app"
+	// ModeOIDC authenticates by exchanging an Actions-issued OIDC token for
+	// a GitHub App installation token, so no private key has to be stored
+	// as a repository secret.
+	ModeOIDC Mode = "oidc"
+)
+
+// Config describes how to authenticate a GitHub client. Only the fields
+// relevant to Mode need to be set.
+type Config struct {
+	Mode Mode
+
+	// Token is used when Mode is ModeToken.
+	Token string
+
+	// AppID and InstallationID are required by both ModeApp and ModeOIDC.
+	// ModeApp uses them to sign the JWT and build the token URL; ModeOIDC
+	// only checks that they are set and does not send them to the broker.
+	AppID          int64
+	InstallationID int64
+
+	// PrivateKeyPEM is the app's private key, used by ModeApp to sign the
+	// JWT exchanged for an installation token.
+	PrivateKeyPEM []byte
+
+	// OIDCRequestURL and OIDCRequestToken come from the Actions runtime
+	// environment (ACTIONS_ID_TOKEN_REQUEST_URL / _TOKEN) and are used by
+	// ModeOIDC to fetch the federated ID token.
+	OIDCRequestURL   string
+	OIDCRequestToken string
+
+	// TokenBrokerURL is the org-operated service ModeOIDC exchanges the
+	// federated ID token with for an installation token. GitHub's own
+	// installation access-tokens endpoint only accepts an app-signed JWT, not
+	// an Actions OIDC token, so OIDC federation necessarily goes through a
+	// broker that holds the app's private key and verifies the token's
+	// claims (repository, workflow ref, etc.) before minting a real
+	// installation token on the caller's behalf.
+	TokenBrokerURL string
+
+	// HTTPClient is used for token-minting requests (JWT/OIDC exchange). It
+	// defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// CacheDir is where the ETag cache is persisted. It defaults to
+	// "github-team-validator/ghcache" under os.TempDir().
+	CacheDir string
+}
+
+// NewClient builds a *github.Client using the authentication mode in cfg.
+// Installation and OIDC-derived tokens refresh automatically before they
+// expire, so a single client can be reused across a long validation run
+// without hitting a 401 partway through. The returned Transport exposes
+// cache/dedup/rate-limit metrics for the caller to report once the run
+// completes.
+func NewClient(ctx context.Context, cfg Config) (*github.Client, *ghcache.Transport, error) {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	var ts oauth2.TokenSource
+	switch cfg.Mode {
+	case ModeToken:
+		if cfg.Token == "" {
+			return nil, nil, fmt.Errorf("auth: token mode requires a token")
+		}
+		ts = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cfg.Token})
+
+	case ModeApp:
+		if cfg.AppID == 0 || cfg.InstallationID == 0 || len(cfg.PrivateKeyPEM) == 0 {
+			return nil, nil, fmt.Errorf("auth: app mode requires app-id, installation-id and a private key")
+		}
+		ts = oauth2.ReuseTokenSource(nil, &installationTokenSource{
+			httpClient: httpClient,
+			tokenURL:   fmt.Sprintf("https://api.github.com/app/installations/%d/access_tokens", cfg.InstallationID),
+			bearer: func(ctx context.Context) (string, error) {
+				return newAppJWT(cfg.AppID, cfg.PrivateKeyPEM)
+			},
+		})
+
+	case ModeOIDC:
+		if cfg.AppID == 0 || cfg.InstallationID == 0 || cfg.OIDCRequestURL == "" || cfg.OIDCRequestToken == "" || cfg.TokenBrokerURL == "" {
+			return nil, nil, fmt.Errorf("auth: oidc mode requires app-id, installation-id, the Actions OIDC request URL/token, and a token-broker-url")
+		}
+		ts = oauth2.ReuseTokenSource(nil, &installationTokenSource{
+			httpClient: httpClient,
+			tokenURL:   cfg.TokenBrokerURL,
+			bearer: func(ctx context.Context) (string, error) {
+				return fetchActionsOIDCToken(ctx, httpClient, cfg.OIDCRequestURL, cfg.OIDCRequestToken)
+			},
+		})
+
+	default:
+		return nil, nil, fmt.Errorf("auth: unknown mode %q", cfg.Mode)
+	}
+
+	cacheDir := cfg.CacheDir
+	if cacheDir == "" {
+		cacheDir = filepath.Join(os.TempDir(), "github-team-validator", "ghcache")
+	}
+
+	cacheTransport, err := ghcache.NewTransport(http.DefaultTransport, cacheDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("auth: building cache transport: %w", err)
+	}
+
+	// oauth2.NewClient reads its base *http.Client from ctx (falling back to
+	// http.DefaultClient), so routing the cache transport in this way makes
+	// it the base of the oauth2.Transport: requests reach it only after the
+	// Authorization header has been set.
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: cacheTransport})
+
+	return github.NewClient(oauth2.NewClient(ctx, ts)), cacheTransport, nil
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// ConfigFromEnv builds a Config from the environment. The mode is always
+// read from an explicit `auth-mode` input ("token", "app", or "oidc"),
+// defaulting to "token" when unset — it is never inferred from which
+// ambient env vars happen to be present. In particular,
+// ACTIONS_ID_TOKEN_REQUEST_URL is set on any Actions job with `id-token:
+// write`, a permission many jobs carry for unrelated reasons, so its mere
+// presence must not silently switch a caller who configured GitHub App auth
+// into OIDC mode.
+func ConfigFromEnv(tokenEnvVar string) (Config, error) {
+	mode := firstNonEmpty(os.Getenv("INPUT_AUTH-MODE"), os.Getenv("AUTH_MODE"))
+	if mode == "" {
+		mode = string(ModeToken)
+	}
+
+	switch Mode(mode) {
+	case ModeToken:
+		return Config{Mode: ModeToken, Token: os.Getenv(tokenEnvVar)}, nil
+
+	case ModeApp:
+		appID, installationID, err := parseAppIDs()
+		if err != nil {
+			return Config{}, err
+		}
+		privateKey := firstNonEmpty(os.Getenv("INPUT_PRIVATE-KEY"), os.Getenv("APP_PRIVATE_KEY"))
+		if privateKey == "" {
+			return Config{}, fmt.Errorf("auth: auth-mode=app requires a private key")
+		}
+		return Config{
+			Mode:           ModeApp,
+			AppID:          appID,
+			InstallationID: installationID,
+			PrivateKeyPEM:  []byte(privateKey),
+		}, nil
+
+	case ModeOIDC:
+		appID, installationID, err := parseAppIDs()
+		if err != nil {
+			return Config{}, err
+		}
+		requestURL := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL")
+		brokerURL := firstNonEmpty(os.Getenv("INPUT_TOKEN-BROKER-URL"), os.Getenv("TOKEN_BROKER_URL"))
+		if requestURL == "" {
+			return Config{}, fmt.Errorf("auth: auth-mode=oidc requires the Actions runtime to provide ACTIONS_ID_TOKEN_REQUEST_URL")
+		}
+		if brokerURL == "" {
+			return Config{}, fmt.Errorf("auth: auth-mode=oidc requires a token-broker-url")
+		}
+		return Config{
+			Mode:             ModeOIDC,
+			AppID:            appID,
+			InstallationID:   installationID,
+			OIDCRequestURL:   requestURL,
+			OIDCRequestToken: os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN"),
+			TokenBrokerURL:   brokerURL,
+		}, nil
+
+	default:
+		return Config{}, fmt.Errorf("auth: unknown auth-mode %q", mode)
+	}
+}
+
+func parseAppIDs() (appID, installationID int64, err error) {
+	appIDRaw := firstNonEmpty(os.Getenv("INPUT_APP-ID"), os.Getenv("APP_ID"))
+	installationIDRaw := firstNonEmpty(os.Getenv("INPUT_INSTALLATION-ID"), os.Getenv("INSTALLATION_ID"))
+
+	appID, err = strconv.ParseInt(appIDRaw, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("auth: invalid app-id %q: %w", appIDRaw, err)
+	}
+	installationID, err = strconv.ParseInt(installationIDRaw, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("auth: invalid installation-id %q: %w", installationIDRaw, err)
+	}
+	return appID, installationID, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// installationTokenSource mints an installation token on demand by POSTing
+// to tokenURL. bearer produces the credential presented there: for ModeApp
+// that's a signed app JWT sent straight to GitHub's own access-tokens
+// endpoint; for ModeOIDC it's a federated Actions ID token sent to an
+// org-operated broker instead, since GitHub has no endpoint that accepts an
+// OIDC token in place of an app JWT. Either way the response is expected to
+// have the same {token, expires_at} shape GitHub's endpoint returns.
+type installationTokenSource struct {
+	httpClient *http.Client
+	tokenURL   string
+	bearer     func(ctx context.Context) (string, error)
+}
+
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	ctx := context.Background()
+
+	bearer, err := s.bearer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("auth: minting bearer credential: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("auth: building installation token request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth: requesting installation token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("auth: installation token request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("auth: decoding installation token response: %w", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: result.Token,
+		TokenType:   "Bearer",
+		Expiry:      result.ExpiresAt,
+	}, nil
+}
+
+// newAppJWT signs a short-lived JWT identifying the GitHub App, as required
+// to call the installation access-tokens endpoint.
+func newAppJWT(appID int64, privateKeyPEM []byte) (string, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return "", fmt.Errorf("auth: parsing app private key: %w", err)
+	}
+
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		IssuedAt:  jwt.NewNumericDate(now.Add(-30 * time.Second)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+		Issuer:    fmt.Sprintf("%d", appID),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	return token.SignedString(key)
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// fetchActionsOIDCToken requests a federated ID token from the Actions
+// runtime (the ACTIONS_ID_TOKEN_REQUEST_URL / _TOKEN pair injected into every
+// job). The token is presented to a token-broker service (Config.TokenBrokerURL)
+// that exchanges it for a real installation token, so no app private key
+// needs to be stored as a repository secret.
+func fetchActionsOIDCToken(ctx context.Context, httpClient *http.Client, requestURL, requestToken string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL+"&audience=github-team-validator", nil)
+	if err != nil {
+		return "", fmt.Errorf("auth: building OIDC token request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("auth: requesting OIDC token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("auth: OIDC token request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("auth: decoding OIDC token response: %w", err)
+	}
+	if result.Value == "" {
+		return "", fmt.Errorf("auth: OIDC token response did not contain a value")
+	}
+
+	return result.Value, nil
+}
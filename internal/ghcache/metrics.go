@@ -0,0 +1,57 @@
+package ghcache
+
+import "sync"
+
+// Metrics tracks how effective caching and deduplication were across a run,
+// so the reporter can surface them in the final PR comment.
+type Metrics struct {
+	mu sync.Mutex
+
+	// CallsMade is every HTTP request actually sent to the GitHub API.
+	CallsMade int
+	// CacheHits is requests whose singleflight call was shared with a
+	// duplicate in-flight request. Every caller of a shared call is counted,
+	// including the one whose request actually reached the network.
+	CacheHits int
+	// NotModified is 304 responses from a conditional request, which do not
+	// count against the primary rate limit.
+	NotModified int
+	// Retries is requests retried after a rate-limit response.
+	Retries int
+}
+
+// Snapshot returns a copy of the current counters.
+func (m *Metrics) Snapshot() Metrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return Metrics{
+		CallsMade:   m.CallsMade,
+		CacheHits:   m.CacheHits,
+		NotModified: m.NotModified,
+		Retries:     m.Retries,
+	}
+}
+
+func (m *Metrics) addCall() {
+	m.mu.Lock()
+	m.CallsMade++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) addCacheHit() {
+	m.mu.Lock()
+	m.CacheHits++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) addNotModified() {
+	m.mu.Lock()
+	m.NotModified++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) addRetry() {
+	m.mu.Lock()
+	m.Retries++
+	m.mu.Unlock()
+}
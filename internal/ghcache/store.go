@@ -0,0 +1,75 @@
+package ghcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// entry is the on-disk representation of a cached, conditionally-revalidated
+// response.
+type entry struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// store persists one entry per URL+auth-hash key under a base directory.
+// It is deliberately simple (one file per key, whole-file rewrite) since a
+// single validation run makes at most a few hundred cacheable requests.
+type store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func newStore(dir string) (*store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("ghcache: creating cache dir %s: %w", dir, err)
+	}
+	return &store{dir: dir}, nil
+}
+
+func (s *store) path(key string) string {
+	return filepath.Join(s.dir, key+".json")
+}
+
+func (s *store) load(key string) (*entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false
+	}
+	return &e, true
+}
+
+func (s *store) save(key string, e *entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("ghcache: encoding cache entry: %w", err)
+	}
+	return os.WriteFile(s.path(key), data, 0o644)
+}
+
+// cacheKey identifies a request by its URL and the credential that
+// authenticated it, so cache entries are never replayed across identities.
+func cacheKey(authHeader, url string) string {
+	sum := sha256.Sum256([]byte(authHeader + "|" + url))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,227 @@
+// Package ghcache wraps an http.RoundTripper with ETag-aware on-disk
+// caching, in-run request coalescing, and rate-limit backoff, so a
+// validation run touching many files doesn't re-fetch unchanged data or
+// trip GitHub's primary/secondary rate limits.
+package ghcache
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const maxRateLimitRetries = 5
+
+// Transport is an http.RoundTripper meant to sit as the base transport
+// behind an oauth2.Transport (wired in via oauth2.NewClient's context
+// client), so it observes requests after the Authorization header has
+// already been set.
+type Transport struct {
+	base    http.RoundTripper
+	store   *store
+	group   singleflight.Group
+	metrics *Metrics
+}
+
+// NewTransport builds a Transport that persists its cache under cacheDir. A
+// nil base defaults to http.DefaultTransport.
+func NewTransport(base http.RoundTripper, cacheDir string) (*Transport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	s, err := newStore(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transport{
+		base:    base,
+		store:   s,
+		metrics: &Metrics{},
+	}, nil
+}
+
+// Metrics returns a snapshot of the counters accumulated so far.
+func (t *Transport) Metrics() Metrics {
+	return t.metrics.Snapshot()
+}
+
+// RoundTrip caches and deduplicates GET requests; any other method is
+// forwarded straight through the rate-limit-aware sender, uncached.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.sendWithBackoff(req)
+	}
+
+	key := cacheKey(req.Header.Get("Authorization"), req.URL.String())
+
+	v, err, shared := t.group.Do(key, func() (interface{}, error) {
+		return t.fetch(req, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		t.metrics.addCacheHit()
+	}
+
+	return v.(*entry).response(req), nil
+}
+
+func (t *Transport) fetch(req *http.Request, key string) (*entry, error) {
+	cached, hasCached := t.store.load(key)
+
+	sendReq := req.Clone(req.Context())
+	if hasCached {
+		if cached.ETag != "" {
+			sendReq.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			sendReq.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := t.sendWithBackoff(sendReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ghcache: reading response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		t.metrics.addNotModified()
+		return cached, nil
+	}
+
+	result := &entry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+	}
+
+	if resp.StatusCode == http.StatusOK && result.ETag != "" {
+		if err := t.store.save(key, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// sendWithBackoff sends req, retrying with exponential backoff plus jitter
+// when GitHub reports a primary or secondary rate limit, honoring
+// Retry-After / X-RateLimit-Reset when present. Every attempt after the
+// first is sent on a fresh clone with its body re-seeded from GetBody: the
+// first attempt's body reader is fully drained by the time a rate-limit
+// response comes back, so retrying on req itself would send an empty (or
+// truncated) body for the non-GET calls that route through here, silently
+// corrupting a check-run update or PR comment instead of failing loudly.
+func (t *Transport) sendWithBackoff(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			cloned, err := cloneRequestBody(req)
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = cloned
+		}
+
+		t.metrics.addCall()
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isRateLimited(resp) || attempt >= maxRateLimitRetries {
+			return resp, nil
+		}
+
+		wait := backoffDuration(resp, attempt)
+		resp.Body.Close()
+		t.metrics.addRetry()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// cloneRequestBody clones req and resets its Body from GetBody, so a retried
+// request doesn't send an already-drained reader. A request with a non-nil
+// Body but no GetBody (not possible via http.NewRequest's own body types,
+// but possible from a hand-built *http.Request) can't be safely retried.
+func cloneRequestBody(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return clone, nil
+	}
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("ghcache: cannot retry request to %s: body has no GetBody", req.URL)
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("ghcache: resetting request body for retry: %w", err)
+	}
+	clone.Body = body
+	return clone, nil
+}
+
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
+func backoffDuration(resp *http.Response, attempt int) time.Duration {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(secs)*time.Second + jitter()
+		}
+	}
+
+	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
+		if ts, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			if wait := time.Until(time.Unix(ts, 0)); wait > 0 {
+				return wait + jitter()
+			}
+		}
+	}
+
+	return time.Duration(1<<attempt)*time.Second + jitter()
+}
+
+func jitter() time.Duration {
+	return time.Duration(rand.Int63n(int64(250 * time.Millisecond)))
+}
+
+// response reconstructs an *http.Response from a stored entry. A fresh copy
+// is built per call so multiple callers (e.g. a singleflight fan-out) can
+// each read their own Body without racing.
+func (e *entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		StatusCode: e.StatusCode,
+		Status:     fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		Header:     e.Header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(e.Body)),
+		Request:    req,
+	}
+}
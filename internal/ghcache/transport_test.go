@@ -0,0 +1,149 @@
+package ghcache
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func newTestTransport(t *testing.T, base http.RoundTripper) *Transport {
+	t.Helper()
+	tr, err := NewTransport(base, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewTransport: %v", err)
+	}
+	return tr
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestTransportReplays304FromCache(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	tr := newTestTransport(t, http.DefaultTransport)
+	client := &http.Client{Transport: tr}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("GET #%d: %v", i, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != "hello" {
+			t.Errorf("GET #%d: body = %q, want %q", i, body, "hello")
+		}
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected both requests to reach the server (one fresh, one conditional), got %d calls", calls)
+	}
+	metrics := tr.Metrics()
+	if metrics.NotModified != 1 {
+		t.Errorf("expected 1 not-modified response recorded, got %d", metrics.NotModified)
+	}
+}
+
+func TestTransportCoalescesConcurrentGETs(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	tr := newTestTransport(t, http.DefaultTransport)
+	client := &http.Client{Transport: tr}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("GET: %v", err)
+				return
+			}
+			io.ReadAll(resp.Body)
+			resp.Body.Close()
+		}()
+	}
+
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected concurrent duplicate requests to be coalesced into 1 call, got %d", calls)
+	}
+	// singleflight.Group reports "shared" for every caller of a duplicated
+	// call, including the one that actually issued it, so all 5 count as
+	// cache hits here even though only 1 request reached the server.
+	if tr.Metrics().CacheHits != 5 {
+		t.Errorf("expected all 5 callers to be recorded as cache hits, got %d", tr.Metrics().CacheHits)
+	}
+}
+
+func TestSendWithBackoffRetriesWithFreshBody(t *testing.T) {
+	var attempt int32
+	var secondAttemptBody []byte
+
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&attempt, 1)
+		if n == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"0"}},
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		}
+
+		body, _ := io.ReadAll(req.Body)
+		secondAttemptBody = body
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte("ok"))),
+		}, nil
+	})
+
+	tr := newTestTransport(t, base)
+
+	req, err := http.NewRequest(http.MethodPost, "http://example.invalid/check-runs", bytes.NewReader([]byte(`{"status":"completed"}`)))
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+
+	if attempt != 2 {
+		t.Fatalf("expected exactly one retry, got %d attempts", attempt)
+	}
+	if string(secondAttemptBody) != `{"status":"completed"}` {
+		t.Errorf("retried request body = %q, want the original payload intact", secondAttemptBody)
+	}
+}
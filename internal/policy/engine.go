@@ -0,0 +1,170 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Severity classifies how serious a violation is. The values match the
+// Checks API's annotation_level field directly.
+type Severity string
+
+const (
+	SeverityFailure Severity = "failure"
+	SeverityWarning Severity = "warning"
+	SeverityNotice  Severity = "notice"
+)
+
+// Violation is a single policy rule failure attributed to one manifest.
+type Violation struct {
+	File    string
+	Field   string
+	Message string
+	// Line is the 1-based source line Field was found on, filled in by
+	// AttachLines. It is 0 when unknown.
+	Line int
+	// Severity is the annotation level this violation should be reported at.
+	// The zero value is treated as SeverityFailure, so violations raised
+	// outside the engine (e.g. a file that failed to read or parse) don't
+	// need to set it explicitly.
+	Severity Severity
+}
+
+// IsFailure reports whether v should fail the overall check run, treating an
+// unset Severity as SeverityFailure.
+func (v Violation) IsFailure() bool {
+	return v.Severity == "" || v.Severity == SeverityFailure
+}
+
+func (v Violation) String() string {
+	if v.Field != "" {
+		return fmt.Sprintf("%s: %s (%s)", v.File, v.Message, v.Field)
+	}
+	return fmt.Sprintf("%s: %s", v.File, v.Message)
+}
+
+// Evaluator evaluates a single manifest and returns the violations found.
+// Engine implements it for the declarative YAML rules, RegoEngine for a
+// `.rego` module.
+type Evaluator interface {
+	Evaluate(file string, obj *unstructured.Unstructured) []Violation
+}
+
+// Engine evaluates manifests against a fixed Policy. It is safe to reuse
+// across files within a single run.
+type Engine struct {
+	policy *Policy
+}
+
+// NewEngine builds an Engine for the given policy.
+func NewEngine(p *Policy) *Engine {
+	return &Engine{policy: p}
+}
+
+// Evaluate runs every applicable rule against obj and returns all violations
+// found, rather than stopping at the first one.
+func (e *Engine) Evaluate(file string, obj *unstructured.Unstructured) []Violation {
+	var violations []Violation
+
+	annotations := obj.GetAnnotations()
+	for _, key := range e.policy.RequiredAnnotations {
+		if annotations[key] == "" {
+			violations = append(violations, Violation{
+				File:     file,
+				Field:    fmt.Sprintf("metadata.annotations.%s", key),
+				Message:  fmt.Sprintf("required annotation %q is missing", key),
+				Severity: e.policy.severityFor("requiredAnnotations"),
+			})
+		}
+	}
+
+	if team := annotations["team"]; team != "" && len(e.policy.AllowedTeamPrefixes) > 0 {
+		if !hasAnyPrefix(team, e.policy.AllowedTeamPrefixes) {
+			violations = append(violations, Violation{
+				File:     file,
+				Field:    "metadata.annotations.team",
+				Message:  fmt.Sprintf("team %q does not match any allowed prefix %v", team, e.policy.AllowedTeamPrefixes),
+				Severity: e.policy.severityFor("teamPrefix"),
+			})
+		}
+	}
+
+	for _, path := range e.policy.ForbiddenFields {
+		if fieldExists(obj, path) {
+			violations = append(violations, Violation{
+				File:     file,
+				Field:    path,
+				Message:  "forbidden field is set",
+				Severity: e.policy.severityFor("forbiddenFields"),
+			})
+		}
+	}
+
+	if obj.GetKind() == "ResourceQuota" {
+		violations = append(violations, e.evaluateQuota(file, obj)...)
+	}
+
+	return violations
+}
+
+func (e *Engine) evaluateQuota(file string, obj *unstructured.Unstructured) []Violation {
+	bounds, ok := e.policy.Quotas[obj.GetNamespace()]
+	if !ok {
+		if bounds, ok = e.policy.Quotas["*"]; !ok {
+			return nil
+		}
+	}
+
+	hard, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "hard")
+
+	severity := e.policy.severityFor("quota")
+	var violations []Violation
+	if v := checkQuotaBound(hard["cpu"], bounds.MaxCPU); v != "" {
+		violations = append(violations, Violation{File: file, Field: "spec.hard.cpu", Message: v, Severity: severity})
+	}
+	if v := checkQuotaBound(hard["memory"], bounds.MaxMemory); v != "" {
+		violations = append(violations, Violation{File: file, Field: "spec.hard.memory", Message: v, Severity: severity})
+	}
+
+	return violations
+}
+
+// checkQuotaBound compares a requested resource.Quantity string against a
+// bound, returning a violation message if the request exceeds it (or if
+// either value fails to parse as a quantity) and "" otherwise.
+func checkQuotaBound(requested, max string) string {
+	if requested == "" || max == "" {
+		return ""
+	}
+
+	requestedQty, err := resource.ParseQuantity(requested)
+	if err != nil {
+		return fmt.Sprintf("requested value %q is not a valid resource quantity", requested)
+	}
+	maxQty, err := resource.ParseQuantity(max)
+	if err != nil {
+		return fmt.Sprintf("policy max %q is not a valid resource quantity", max)
+	}
+
+	if requestedQty.Cmp(maxQty) > 0 {
+		return fmt.Sprintf("requested %q exceeds max %q", requested, max)
+	}
+	return ""
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldExists(obj *unstructured.Unstructured, dottedPath string) bool {
+	_, found, _ := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(dottedPath, ".")...)
+	return found
+}
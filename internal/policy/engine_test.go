@@ -0,0 +1,173 @@
+package policy
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func namespace(annotations map[string]interface{}) *unstructured.Unstructured {
+	obj := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata": map[string]interface{}{
+			"name": "payments",
+		},
+	}
+	if annotations != nil {
+		obj["metadata"].(map[string]interface{})["annotations"] = annotations
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func TestEvaluateRequiredAnnotations(t *testing.T) {
+	engine := NewEngine(&Policy{RequiredAnnotations: []string{"team", "source-code"}})
+
+	violations := engine.Evaluate("namespaces/payments.yaml", namespace(map[string]interface{}{"team": "platform-infra"}))
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation for the missing source-code annotation, got %d: %v", len(violations), violations)
+	}
+	if violations[0].Field != "metadata.annotations.source-code" {
+		t.Errorf("unexpected field: %q", violations[0].Field)
+	}
+	if !violations[0].IsFailure() {
+		t.Errorf("expected a default-severity violation to be a failure")
+	}
+
+	if got := engine.Evaluate("namespaces/payments.yaml", namespace(map[string]interface{}{
+		"team":        "platform-infra",
+		"source-code": "https://github.com/org/payments",
+	})); len(got) != 0 {
+		t.Errorf("expected no violations when every required annotation is present, got %v", got)
+	}
+}
+
+func TestEvaluateTeamPrefix(t *testing.T) {
+	engine := NewEngine(&Policy{AllowedTeamPrefixes: []string{"platform-", "data-"}})
+
+	violations := engine.Evaluate("namespaces/payments.yaml", namespace(map[string]interface{}{"team": "marketing-growth"}))
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation for a disallowed team prefix, got %d", len(violations))
+	}
+
+	if got := engine.Evaluate("namespaces/payments.yaml", namespace(map[string]interface{}{"team": "data-ingest"})); len(got) != 0 {
+		t.Errorf("expected no violations for an allowed team prefix, got %v", got)
+	}
+}
+
+func TestEvaluateForbiddenFields(t *testing.T) {
+	engine := NewEngine(&Policy{ForbiddenFields: []string{"spec.hostNetwork"}})
+
+	withField := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "x"},
+		"spec":       map[string]interface{}{"hostNetwork": true},
+	}}
+	if violations := engine.Evaluate("pod.yaml", withField); len(violations) != 1 {
+		t.Fatalf("expected 1 violation for a forbidden field that's set, got %d", len(violations))
+	}
+
+	withoutField := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "x"},
+		"spec":       map[string]interface{}{},
+	}}
+	if violations := engine.Evaluate("pod.yaml", withoutField); len(violations) != 0 {
+		t.Errorf("expected no violations when the forbidden field is unset, got %v", violations)
+	}
+}
+
+func resourceQuota(namespace, cpu, memory string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ResourceQuota",
+		"metadata":   map[string]interface{}{"name": "quota", "namespace": namespace},
+		"spec": map[string]interface{}{
+			"hard": map[string]interface{}{
+				"cpu":    cpu,
+				"memory": memory,
+			},
+		},
+	}}
+}
+
+func TestEvaluateQuotaBounds(t *testing.T) {
+	tests := []struct {
+		name           string
+		quotas         map[string]QuotaBounds
+		namespace      string
+		cpu, memory    string
+		wantViolations int
+	}{
+		{
+			name:           "within bounds via exact namespace match",
+			quotas:         map[string]QuotaBounds{"payments": {MaxCPU: "4", MaxMemory: "8Gi"}},
+			namespace:      "payments",
+			cpu:            "2",
+			memory:         "4Gi",
+			wantViolations: 0,
+		},
+		{
+			name:           "cpu exceeds bound",
+			quotas:         map[string]QuotaBounds{"payments": {MaxCPU: "4", MaxMemory: "8Gi"}},
+			namespace:      "payments",
+			cpu:            "8",
+			memory:         "4Gi",
+			wantViolations: 1,
+		},
+		{
+			name:           "memory uses binary-unit comparison, not string comparison",
+			quotas:         map[string]QuotaBounds{"payments": {MaxCPU: "4", MaxMemory: "1Gi"}},
+			namespace:      "payments",
+			cpu:            "2",
+			memory:         "512Mi",
+			wantViolations: 0,
+		},
+		{
+			name:           "falls back to the wildcard bound",
+			quotas:         map[string]QuotaBounds{"*": {MaxCPU: "1", MaxMemory: "1Gi"}},
+			namespace:      "anything",
+			cpu:            "2",
+			memory:         "512Mi",
+			wantViolations: 1,
+		},
+		{
+			name:           "no bound configured for this namespace",
+			quotas:         map[string]QuotaBounds{"other": {MaxCPU: "1", MaxMemory: "1Gi"}},
+			namespace:      "payments",
+			cpu:            "1000",
+			memory:         "1000Gi",
+			wantViolations: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			engine := NewEngine(&Policy{Quotas: tc.quotas})
+			violations := engine.Evaluate("quota.yaml", resourceQuota(tc.namespace, tc.cpu, tc.memory))
+			if len(violations) != tc.wantViolations {
+				t.Fatalf("expected %d violations, got %d: %v", tc.wantViolations, len(violations), violations)
+			}
+		})
+	}
+}
+
+func TestEvaluateSeverityOverride(t *testing.T) {
+	engine := NewEngine(&Policy{
+		RequiredAnnotations: []string{"team"},
+		Severity:            map[string]Severity{"requiredAnnotations": SeverityWarning},
+	})
+
+	violations := engine.Evaluate("namespaces/payments.yaml", namespace(nil))
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d", len(violations))
+	}
+	if violations[0].Severity != SeverityWarning {
+		t.Errorf("expected severity %q, got %q", SeverityWarning, violations[0].Severity)
+	}
+	if violations[0].IsFailure() {
+		t.Errorf("a warning-severity violation should not be a failure")
+	}
+}
@@ -0,0 +1,64 @@
+package policy
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LineIndex maps dotted manifest field paths (as used in Violation.Field) to
+// the source line they appear on. It is built independently of
+// ParseManifest, since sigs.k8s.io/yaml's JSON round-trip discards line
+// information that yaml.v3's node tree retains.
+type LineIndex struct {
+	root *yaml.Node
+}
+
+// NewLineIndex parses data purely to retain line numbers for later lookup.
+func NewLineIndex(data []byte) (*LineIndex, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return &LineIndex{root: &doc}, nil
+}
+
+// Line returns the 1-based source line of the given dotted field path (e.g.
+// "metadata.annotations.team"), or 0 if the path can't be resolved.
+func (idx *LineIndex) Line(dottedPath string) int {
+	if idx == nil || idx.root == nil || len(idx.root.Content) == 0 || dottedPath == "" {
+		return 0
+	}
+
+	node := idx.root.Content[0]
+	for _, seg := range strings.Split(dottedPath, ".") {
+		node = mappingValue(node, seg)
+		if node == nil {
+			return 0
+		}
+	}
+	return node.Line
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// AttachLines fills in Line on every violation that has a Field, using idx
+// to resolve it. Violations without a resolvable field (or idx == nil) are
+// left with Line 0.
+func AttachLines(violations []Violation, idx *LineIndex) {
+	for i := range violations {
+		if violations[i].Field != "" {
+			violations[i].Line = idx.Line(violations[i].Field)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Package policy parses Kubernetes-style manifests into unstructured objects
+// and evaluates them against a declarative policy so that namespace
+// annotations, labels, and spec fields can all be inspected without a
+// hand-rolled struct per resource kind.
+package policy
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// ParseManifest decodes a single Kubernetes manifest document into an
+// unstructured object. Any well-formed YAML/JSON object that sets a `kind`
+// is accepted (one without a kind is rejected); callers are expected to
+// check GetKind()/GetAPIVersion() before relying on resource-specific fields.
+func ParseManifest(data []byte) (*unstructured.Unstructured, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+
+	obj := &unstructured.Unstructured{Object: raw}
+	if obj.GetKind() == "" {
+		return nil, fmt.Errorf("manifest is missing a kind")
+	}
+
+	return obj, nil
+}
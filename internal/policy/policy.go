@@ -0,0 +1,121 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+// QuotaBounds caps the resources a ResourceQuota manifest is allowed to
+// request for a given namespace.
+type QuotaBounds struct {
+	MaxCPU    string `json:"maxCPU,omitempty"`
+	MaxMemory string `json:"maxMemory,omitempty"`
+}
+
+// Policy is a declarative set of rules evaluated against every manifest in a
+// run. It is loaded from a YAML file referenced by the action's
+// `policy-file` input.
+//
+// Rules are expressed as fixed fields (required annotations, team-prefix
+// restrictions, forbidden fields, quota bounds) rather than an embedded rule
+// language. A `policy-file` ending in ".rego" instead compiles to a
+// RegoEngine — see LoadEngine.
+type Policy struct {
+	// RequiredAnnotations must be present (and non-empty) under
+	// metadata.annotations on every manifest.
+	RequiredAnnotations []string `json:"requiredAnnotations,omitempty"`
+	// AllowedTeamPrefixes, if set, restricts the `team` annotation to values
+	// starting with one of these prefixes (e.g. "platform-", "data-").
+	AllowedTeamPrefixes []string `json:"allowedTeamPrefixes,omitempty"`
+	// ForbiddenFields is a list of dotted field paths (e.g.
+	// "spec.hostNetwork") that must not be set on any manifest.
+	ForbiddenFields []string `json:"forbiddenFields,omitempty"`
+	// Quotas bounds ResourceQuota manifests per namespace name. The "*" key
+	// applies to any namespace without a more specific entry.
+	Quotas map[string]QuotaBounds `json:"quotas,omitempty"`
+	// PermittedTeams restricts team-membership checks for files matching a
+	// glob key (e.g. "namespaces/critical/*.yaml") to the listed team slugs,
+	// even if the file's own annotations or CODEOWNERS entry name others.
+	PermittedTeams map[string][]string `json:"permittedTeams,omitempty"`
+	// Severity overrides the default annotation_level ("failure") for a rule
+	// category: "requiredAnnotations", "teamPrefix", "forbiddenFields", or
+	// "quota". A category absent from this map stays a failure.
+	Severity map[string]Severity `json:"severity,omitempty"`
+}
+
+// severityFor returns the configured Severity for a rule category, or
+// SeverityFailure if the policy doesn't override it.
+func (p *Policy) severityFor(category string) Severity {
+	if s, ok := p.Severity[category]; ok && s != "" {
+		return s
+	}
+	return SeverityFailure
+}
+
+// PermittedTeamsFor returns the permitted-teams restriction that applies to
+// file, or nil if none of the configured glob keys match it. When more than
+// one glob key matches the same file, the most specific pattern (the
+// longest one) wins, with equal-length patterns ordered lexically; ranging
+// over PermittedTeams directly would leave the
+// result to Go's randomized map iteration order, which is not an
+// acceptable source of nondeterminism for an access-control decision.
+func (p *Policy) PermittedTeamsFor(file string) []string {
+	var matched []string
+	for pattern := range p.PermittedTeams {
+		if ok, err := filepath.Match(pattern, file); err == nil && ok {
+			matched = append(matched, pattern)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if len(matched[i]) != len(matched[j]) {
+			return len(matched[i]) > len(matched[j])
+		}
+		return matched[i] < matched[j]
+	})
+	return p.PermittedTeams[matched[0]]
+}
+
+// LoadPolicy reads and parses a declarative policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy file %s: %w", path, err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing policy file %s: %w", path, err)
+	}
+
+	return &p, nil
+}
+
+// LoadEngine loads the rules at path and returns the Evaluator to run them
+// with. A ".rego" extension compiles path as a Rego module (RegoEngine);
+// any other extension is parsed as a declarative YAML Policy (Engine). The
+// second return value is the parsed Policy when one was loaded, or nil for
+// a Rego module — callers that need YAML-only features like
+// PermittedTeamsFor should guard on it being non-nil.
+func LoadEngine(path string) (Evaluator, *Policy, error) {
+	if filepath.Ext(path) == ".rego" {
+		engine, err := LoadRegoEngine(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return engine, nil, nil
+	}
+
+	p, err := LoadPolicy(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewEngine(p), p, nil
+}
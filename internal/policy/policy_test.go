@@ -0,0 +1,39 @@
+package policy
+
+import "testing"
+
+func TestPermittedTeamsFor(t *testing.T) {
+	p := &Policy{
+		PermittedTeams: map[string][]string{
+			"namespaces/critical/*.yaml": {"platform-infra"},
+		},
+	}
+
+	got := p.PermittedTeamsFor("namespaces/critical/payments.yaml")
+	if len(got) != 1 || got[0] != "platform-infra" {
+		t.Fatalf("expected [platform-infra] for a matching critical namespace, got %v", got)
+	}
+
+	if got := p.PermittedTeamsFor("namespaces/general/payments.yaml"); got != nil {
+		t.Errorf("expected no restriction for a non-matching namespace, got %v", got)
+	}
+}
+
+// TestPermittedTeamsForPicksMostSpecificMatch guards against the bug where
+// two overlapping glob keys matching the same file resolved via Go's
+// randomized map iteration order instead of a deterministic rule.
+func TestPermittedTeamsForPicksMostSpecificMatch(t *testing.T) {
+	p := &Policy{
+		PermittedTeams: map[string][]string{
+			"namespaces/*/*.yaml":        {"platform-infra"},
+			"namespaces/critical/*.yaml": {"security"},
+		},
+	}
+
+	for i := 0; i < 20; i++ {
+		got := p.PermittedTeamsFor("namespaces/critical/payments.yaml")
+		if len(got) != 1 || got[0] != "security" {
+			t.Fatalf("expected the more specific pattern's team [security] to win, got %v", got)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func writeRegoModule(t *testing.T, src string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.rego")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("writing rego module: %v", err)
+	}
+	return path
+}
+
+func TestRegoEngineEvaluate(t *testing.T) {
+	path := writeRegoModule(t, `package policy
+
+deny[msg] {
+	not input.metadata.annotations.team
+	msg := "team annotation is required"
+}
+
+deny[v] {
+	input.metadata.annotations.team == "banned-team"
+	v := {"msg": "team is not allowed to own this namespace", "field": "metadata.annotations.team", "severity": "warning"}
+}
+`)
+
+	engine, err := LoadRegoEngine(path)
+	if err != nil {
+		t.Fatalf("LoadRegoEngine: %v", err)
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{"team": "banned-team"},
+		},
+	}}
+
+	violations := engine.Evaluate("namespaces/payments.yaml", obj)
+	if len(violations) != 1 {
+		t.Fatalf("expected exactly 1 violation, got %d: %+v", len(violations), violations)
+	}
+	v := violations[0]
+	if v.Message != "team is not allowed to own this namespace" || v.Field != "metadata.annotations.team" || v.Severity != SeverityWarning {
+		t.Errorf("unexpected violation: %+v", v)
+	}
+}
+
+func TestRegoEngineEvaluateNoViolations(t *testing.T) {
+	path := writeRegoModule(t, `package policy
+
+deny[msg] {
+	not input.metadata.annotations.team
+	msg := "team annotation is required"
+}
+`)
+
+	engine, err := LoadRegoEngine(path)
+	if err != nil {
+		t.Fatalf("LoadRegoEngine: %v", err)
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{"team": "platform-infra"},
+		},
+	}}
+
+	if got := engine.Evaluate("namespaces/payments.yaml", obj); len(got) != 0 {
+		t.Errorf("expected no violations, got %+v", got)
+	}
+}
+
+func TestLoadEngineDispatchesOnExtension(t *testing.T) {
+	regoPath := writeRegoModule(t, `package policy
+
+deny[msg] { msg := "always denied" }
+`)
+	engine, pol, err := LoadEngine(regoPath)
+	if err != nil {
+		t.Fatalf("LoadEngine: %v", err)
+	}
+	if _, ok := engine.(*RegoEngine); !ok {
+		t.Errorf("expected a .rego file to load a *RegoEngine, got %T", engine)
+	}
+	if pol != nil {
+		t.Errorf("expected a nil Policy for a rego module, got %+v", pol)
+	}
+
+	yamlPath := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(yamlPath, []byte("requiredAnnotations: [team]\n"), 0o644); err != nil {
+		t.Fatalf("writing yaml policy: %v", err)
+	}
+	engine, pol, err = LoadEngine(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadEngine: %v", err)
+	}
+	if _, ok := engine.(*Engine); !ok {
+		t.Errorf("expected a .yaml file to load an *Engine, got %T", engine)
+	}
+	if pol == nil {
+		t.Error("expected a non-nil Policy for a yaml policy file")
+	}
+}
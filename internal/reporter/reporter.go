@@ -0,0 +1,151 @@
+// Package reporter surfaces policy validation results through the GitHub
+// Checks API, so violations show up as annotations on the Files-changed
+// diff instead of only in a PR comment.
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+
+	"github.com/Gnomon-iterative/github-team-validator/internal/policy"
+)
+
+const checkName = "github-team-validator"
+
+const maxAnnotationsPerUpdate = 50
+
+// Reporter drives a single Check Run from creation through completion.
+type Reporter struct {
+	client *github.Client
+	owner  string
+	repo   string
+	runID  int64
+}
+
+// Start creates an in_progress Check Run for the given commit SHA.
+func Start(ctx context.Context, client *github.Client, owner, repo, sha string) (*Reporter, error) {
+	run, _, err := client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+		Name:    checkName,
+		HeadSHA: sha,
+		Status:  github.String("in_progress"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reporter: creating check run: %w", err)
+	}
+
+	return &Reporter{client: client, owner: owner, repo: repo, runID: run.GetID()}, nil
+}
+
+// Finish updates the Check Run with one annotation per violation and sets
+// its conclusion: "neutral" if no manifests were evaluated, "failure" if any
+// violation at SeverityFailure was found, "success" otherwise. Warnings and
+// notices are still reported as annotations but don't fail the run.
+func (r *Reporter) Finish(ctx context.Context, violations []policy.Violation, filesEvaluated int) error {
+	conclusion := "success"
+	switch {
+	case filesEvaluated == 0:
+		conclusion = "neutral"
+	case hasFailure(violations):
+		conclusion = "failure"
+	}
+
+	annotations := buildAnnotations(violations)
+	if len(annotations) == 0 {
+		return r.update(ctx, true, conclusion, nil, violations)
+	}
+
+	for i := 0; i < len(annotations); i += maxAnnotationsPerUpdate {
+		end := i + maxAnnotationsPerUpdate
+		if end > len(annotations) {
+			end = len(annotations)
+		}
+		final := end == len(annotations)
+		if err := r.update(ctx, final, conclusion, annotations[i:end], violations); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasFailure(violations []policy.Violation) bool {
+	for _, v := range violations {
+		if v.IsFailure() {
+			return true
+		}
+	}
+	return false
+}
+
+// update pushes one batch of annotations. Only the final batch transitions
+// the run to "completed" with a conclusion attached; earlier batches stay
+// "in_progress" so the Checks API keeps accepting further annotations
+// instead of treating the run as already finished.
+func (r *Reporter) update(ctx context.Context, final bool, conclusion string, batch []*github.CheckRunAnnotation, violations []policy.Violation) error {
+	opts := github.UpdateCheckRunOptions{
+		Name: checkName,
+		Output: &github.CheckRunOutput{
+			Title:       github.String(summaryTitle(len(violations))),
+			Summary:     github.String(summary(violations)),
+			Annotations: batch,
+		},
+	}
+	if final {
+		opts.Status = github.String("completed")
+		opts.Conclusion = github.String(conclusion)
+	} else {
+		opts.Status = github.String("in_progress")
+	}
+
+	_, _, err := r.client.Checks.UpdateCheckRun(ctx, r.owner, r.repo, r.runID, opts)
+	if err != nil {
+		return fmt.Errorf("reporter: updating check run: %w", err)
+	}
+	return nil
+}
+
+func buildAnnotations(violations []policy.Violation) []*github.CheckRunAnnotation {
+	annotations := make([]*github.CheckRunAnnotation, 0, len(violations))
+	for _, v := range violations {
+		line := v.Line
+		if line <= 0 {
+			line = 1
+		}
+		level := v.Severity
+		if level == "" {
+			level = policy.SeverityFailure
+		}
+		annotations = append(annotations, &github.CheckRunAnnotation{
+			Path:            github.String(v.File),
+			StartLine:       github.Int(line),
+			EndLine:         github.Int(line),
+			AnnotationLevel: github.String(string(level)),
+			Title:           github.String("Policy violation"),
+			Message:         github.String(v.Message),
+		})
+	}
+	return annotations
+}
+
+func summaryTitle(n int) string {
+	if n == 0 {
+		return "All policy checks passed"
+	}
+	return fmt.Sprintf("%d policy violation(s) found", n)
+}
+
+func summary(violations []policy.Violation) string {
+	if len(violations) == 0 {
+		return "Every changed manifest satisfied the configured policy."
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d violation(s) found across changed manifests:\n\n", len(violations))
+	for _, v := range violations {
+		fmt.Fprintf(&sb, "- %s\n", v)
+	}
+	return sb.String()
+}
@@ -0,0 +1,130 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+
+	"github.com/Gnomon-iterative/github-team-validator/internal/policy"
+)
+
+func newTestReporter(t *testing.T, capture func(update github.UpdateCheckRunOptions)) *Reporter {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			json.NewEncoder(w).Encode(github.CheckRun{ID: github.Int64(1)})
+		case r.Method == http.MethodPatch:
+			var opts github.UpdateCheckRunOptions
+			json.NewDecoder(r.Body).Decode(&opts)
+			capture(opts)
+			json.NewEncoder(w).Encode(github.CheckRun{ID: github.Int64(1)})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	base, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	client.BaseURL = base
+
+	r, err := Start(context.Background(), client, "acme", "infra", "deadbeef")
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	return r
+}
+
+func TestFinishConclusionIgnoresWarnings(t *testing.T) {
+	var updates []github.UpdateCheckRunOptions
+	r := newTestReporter(t, func(u github.UpdateCheckRunOptions) { updates = append(updates, u) })
+
+	violations := []policy.Violation{
+		{File: "a.yaml", Message: "informational note", Severity: policy.SeverityWarning},
+	}
+	if err := r.Finish(context.Background(), violations, 1); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("expected exactly 1 update for <=50 annotations, got %d", len(updates))
+	}
+	if updates[0].Conclusion == nil || *updates[0].Conclusion != "success" {
+		t.Errorf("expected a warning-only run to conclude success, got %v", updates[0].Conclusion)
+	}
+	if got := updates[0].Output.Annotations[0].GetAnnotationLevel(); got != "warning" {
+		t.Errorf("expected annotation_level %q, got %q", "warning", got)
+	}
+}
+
+func TestFinishFailsOnFailureSeverity(t *testing.T) {
+	var updates []github.UpdateCheckRunOptions
+	r := newTestReporter(t, func(u github.UpdateCheckRunOptions) { updates = append(updates, u) })
+
+	violations := []policy.Violation{
+		{File: "a.yaml", Message: "missing annotation", Severity: policy.SeverityFailure},
+	}
+	if err := r.Finish(context.Background(), violations, 1); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	if updates[0].Conclusion == nil || *updates[0].Conclusion != "failure" {
+		t.Errorf("expected conclusion failure, got %v", updates[0].Conclusion)
+	}
+}
+
+func TestFinishOnlyCompletesOnFinalBatch(t *testing.T) {
+	var updates []github.UpdateCheckRunOptions
+	r := newTestReporter(t, func(u github.UpdateCheckRunOptions) { updates = append(updates, u) })
+
+	violations := make([]policy.Violation, 0, 120)
+	for i := 0; i < 120; i++ {
+		violations = append(violations, policy.Violation{File: "a.yaml", Message: "violation", Severity: policy.SeverityFailure})
+	}
+
+	if err := r.Finish(context.Background(), violations, 1); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	if len(updates) != 3 {
+		t.Fatalf("expected 3 batches of <=50 annotations for 120 violations, got %d", len(updates))
+	}
+	for i, u := range updates {
+		final := i == len(updates)-1
+		wantStatus := "in_progress"
+		if final {
+			wantStatus = "completed"
+		}
+		if u.Status == nil || *u.Status != wantStatus {
+			t.Errorf("batch %d: status = %v, want %q", i, u.Status, wantStatus)
+		}
+		if final && (u.Conclusion == nil || *u.Conclusion != "failure") {
+			t.Errorf("batch %d (final): expected conclusion failure, got %v", i, u.Conclusion)
+		}
+		if !final && u.Conclusion != nil {
+			t.Errorf("batch %d: expected no conclusion on a non-final batch, got %v", i, *u.Conclusion)
+		}
+	}
+}
+
+func TestFinishNeutralWhenNoFilesEvaluated(t *testing.T) {
+	var updates []github.UpdateCheckRunOptions
+	r := newTestReporter(t, func(u github.UpdateCheckRunOptions) { updates = append(updates, u) })
+
+	if err := r.Finish(context.Background(), nil, 0); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if updates[0].Conclusion == nil || *updates[0].Conclusion != "neutral" {
+		t.Errorf("expected conclusion neutral when no files were evaluated, got %v", updates[0].Conclusion)
+	}
+}
@@ -0,0 +1,85 @@
+package teams
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// ownerRule is one non-comment line of a CODEOWNERS file: a path pattern and
+// the team slugs (with any "@org/" prefix stripped) that own it.
+type ownerRule struct {
+	pattern string
+	teams   []string
+}
+
+// parseCODEOWNERS extracts path-pattern -> team-slug rules from a CODEOWNERS
+// file. Non-team owners (individual @usernames, email addresses) are
+// ignored, since only teams are meaningful for membership checks here.
+func parseCODEOWNERS(data []byte) []ownerRule {
+	var rules []ownerRule
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		var teams []string
+		for _, owner := range fields[1:] {
+			if slug := teamSlug(owner); slug != "" {
+				teams = append(teams, slug)
+			}
+		}
+		if len(teams) > 0 {
+			rules = append(rules, ownerRule{pattern: fields[0], teams: teams})
+		}
+	}
+
+	return rules
+}
+
+// teamSlug extracts the team slug from a CODEOWNERS owner entry of the form
+// "@org/team-slug". Individual-user and email owners return "".
+func teamSlug(owner string) string {
+	if !strings.HasPrefix(owner, "@") || !strings.Contains(owner, "/") {
+		return ""
+	}
+	parts := strings.SplitN(strings.TrimPrefix(owner, "@"), "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return ""
+	}
+	return parts[1]
+}
+
+// codeownersMatch reports whether a CODEOWNERS path pattern covers path.
+// This implements the common subset of the gitignore-style syntax CODEOWNERS
+// uses: a trailing "/" matches a whole directory, a leading "/" anchors to
+// the repo root (the only anchor this validator supports, since changed
+// files are already repo-relative), and everything else is matched with
+// shell-glob semantics against the full path.
+func codeownersMatch(pattern, path string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(path, pattern)
+	}
+
+	if matched, err := filepath.Match(pattern, path); err == nil && matched {
+		return true
+	}
+
+	// A bare directory name (no glob metacharacters) owns everything below it.
+	if !strings.ContainsAny(pattern, "*?[") {
+		return path == pattern || strings.HasPrefix(path, pattern+"/")
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+package teams
+
+import "testing"
+
+func TestTeamSlug(t *testing.T) {
+	tests := []struct {
+		owner string
+		want  string
+	}{
+		{"@org/platform-infra", "platform-infra"},
+		{"@individual-user", ""},
+		{"someone@example.com", ""},
+		{"@org/", ""},
+	}
+	for _, tc := range tests {
+		if got := teamSlug(tc.owner); got != tc.want {
+			t.Errorf("teamSlug(%q) = %q, want %q", tc.owner, got, tc.want)
+		}
+	}
+}
+
+func TestCodeownersMatch(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"namespaces/critical/", "namespaces/critical/payments.yaml", true},
+		{"namespaces/critical/", "namespaces/general/payments.yaml", false},
+		{"namespaces/*.yaml", "namespaces/payments.yaml", true},
+		{"namespaces/*.yaml", "namespaces/sub/payments.yaml", false},
+		{"namespaces", "namespaces/payments.yaml", true},
+		{"namespaces", "namespaces-other/payments.yaml", false},
+		{"/namespaces/critical/", "namespaces/critical/payments.yaml", true},
+	}
+	for _, tc := range tests {
+		if got := codeownersMatch(tc.pattern, tc.path); got != tc.want {
+			t.Errorf("codeownersMatch(%q, %q) = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestParseCODEOWNERS(t *testing.T) {
+	data := []byte(`
+# comment
+namespaces/critical/ @org/platform-infra @org/security
+namespaces/payments.yaml @individual-user
+`)
+
+	rules := parseCODEOWNERS(data)
+	if len(rules) != 1 {
+		t.Fatalf("expected the individual-owner-only line to be dropped, got %d rules: %+v", len(rules), rules)
+	}
+	if rules[0].pattern != "namespaces/critical/" {
+		t.Errorf("unexpected pattern: %q", rules[0].pattern)
+	}
+	if len(rules[0].teams) != 2 || rules[0].teams[0] != "platform-infra" || rules[0].teams[1] != "security" {
+		t.Errorf("unexpected teams: %v", rules[0].teams)
+	}
+}
+
+func TestCodeownersTeamsLastMatchWins(t *testing.T) {
+	r := &Resolver{codeowners: parseCODEOWNERS([]byte(
+		"namespaces/ @org/platform-infra\n" +
+			"namespaces/critical/ @org/security\n",
+	))}
+
+	if got := r.codeownersTeams("namespaces/critical/payments.yaml"); len(got) != 1 || got[0] != "security" {
+		t.Errorf("expected the more specific, later rule to win, got %v", got)
+	}
+	if got := r.codeownersTeams("namespaces/general/payments.yaml"); len(got) != 1 || got[0] != "platform-infra" {
+		t.Errorf("expected the general rule to apply outside namespaces/critical, got %v", got)
+	}
+}
@@ -0,0 +1,153 @@
+// Package teams resolves whether a user is allowed to own a namespace: it
+// accepts co-ownership by multiple teams, walks child teams recursively so
+// membership in a descendant team counts, and layers CODEOWNERS-derived
+// ownership on top of any explicit team annotation.
+package teams
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Resolver answers team-ownership questions against a GitHub org.
+type Resolver struct {
+	client     *github.Client
+	org        string
+	codeowners []ownerRule
+}
+
+// NewResolver builds a Resolver. codeownersData is the raw contents of a
+// CODEOWNERS file, or nil if the repository doesn't have one.
+func NewResolver(client *github.Client, org string, codeownersData []byte) *Resolver {
+	return &Resolver{
+		client:     client,
+		org:        org,
+		codeowners: parseCODEOWNERS(codeownersData),
+	}
+}
+
+// ParseTeamList splits a `team:` annotation into individual team slugs. It
+// accepts a plain comma-separated value ("team-a,team-b") as well as a YAML
+// flow sequence rendered into the annotation string ("[team-a, team-b]").
+func ParseTeamList(raw string) []string {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "[")
+	raw = strings.TrimSuffix(raw, "]")
+
+	var teams []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.Trim(strings.TrimSpace(part), `"'`)
+		if part != "" {
+			teams = append(teams, part)
+		}
+	}
+	return teams
+}
+
+// TeamsForPath returns the union of annotationTeams and any CODEOWNERS team
+// that owns path, so a namespace is considered co-owned even if its
+// `team:` annotation only lists some of its owners.
+func (r *Resolver) TeamsForPath(path string, annotationTeams []string) []string {
+	teams := append([]string{}, annotationTeams...)
+	teams = append(teams, r.codeownersTeams(path)...)
+	return dedupe(teams)
+}
+
+func (r *Resolver) codeownersTeams(path string) []string {
+	var matched []string
+	for _, rule := range r.codeowners {
+		if codeownersMatch(rule.pattern, path) {
+			matched = rule.teams // CODEOWNERS semantics: the last match wins
+		}
+	}
+	return matched
+}
+
+// IsMember reports whether username is an active member of any of
+// candidateTeams, or of a team reachable by recursively walking their child
+// teams. If permittedTeams is non-empty, candidateTeams is first intersected
+// with that set by exact slug (a candidate that is only a descendant of a
+// permitted team is dropped), and child teams are then walked from the teams
+// that remain, letting critical namespaces be scoped to fewer teams than
+// CODEOWNERS/co-ownership would otherwise allow.
+func (r *Resolver) IsMember(ctx context.Context, username string, candidateTeams, permittedTeams []string) (bool, error) {
+	teams := candidateTeams
+	if len(permittedTeams) > 0 {
+		teams = intersect(candidateTeams, permittedTeams)
+	}
+
+	for _, team := range teams {
+		member, err := r.isMemberOfTeamOrDescendants(ctx, username, team)
+		if err != nil {
+			return false, err
+		}
+		if member {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *Resolver) isMemberOfTeamOrDescendants(ctx context.Context, username, team string) (bool, error) {
+	membership, resp, err := r.client.Teams.GetTeamMembershipBySlug(ctx, r.org, team, username)
+	if err != nil && (resp == nil || resp.StatusCode != 404) {
+		return false, fmt.Errorf("checking membership of %s in team %s: %w", username, team, err)
+	}
+	if err == nil && membership.GetState() == "active" {
+		return true, nil
+	}
+
+	children, resp, err := r.client.Teams.ListChildTeamsByParentSlug(ctx, r.org, team, &github.ListOptions{})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// The team has no children to search, which isn't a validation
+			// failure.
+			return false, nil
+		}
+		return false, fmt.Errorf("listing child teams of %s: %w", team, err)
+	}
+
+	for _, child := range children {
+		member, err := r.isMemberOfTeamOrDescendants(ctx, username, child.GetSlug())
+		if err != nil {
+			return false, err
+		}
+		if member {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func intersect(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, v := range b {
+		inB[v] = true
+	}
+
+	var out []string
+	for _, v := range a {
+		if inB[v] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
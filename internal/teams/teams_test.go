@@ -0,0 +1,170 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+)
+
+func TestParseTeamList(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{"platform-infra", []string{"platform-infra"}},
+		{"platform-infra,data-ingest", []string{"platform-infra", "data-ingest"}},
+		{"[platform-infra, data-ingest]", []string{"platform-infra", "data-ingest"}},
+		{`["platform-infra", "data-ingest"]`, []string{"platform-infra", "data-ingest"}},
+		{"", nil},
+	}
+	for _, tc := range tests {
+		got := ParseTeamList(tc.raw)
+		if len(got) != len(tc.want) {
+			t.Errorf("ParseTeamList(%q) = %v, want %v", tc.raw, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("ParseTeamList(%q) = %v, want %v", tc.raw, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+// memberships maps "team/username" -> membership state, and children maps
+// "team" -> its direct child team slugs.
+type fakeTeamsAPI struct {
+	memberships map[string]string
+	children    map[string][]string
+}
+
+var (
+	membershipPath = regexp.MustCompile(`^/orgs/[^/]+/teams/([^/]+)/memberships/([^/]+)$`)
+	childTeamsPath = regexp.MustCompile(`^/orgs/[^/]+/teams/([^/]+)/teams$`)
+)
+
+func (f *fakeTeamsAPI) handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case membershipPath.MatchString(r.URL.Path):
+			m := membershipPath.FindStringSubmatch(r.URL.Path)
+			state, ok := f.memberships[m[1]+"/"+m[2]]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(github.Membership{State: github.String(state)})
+
+		case childTeamsPath.MatchString(r.URL.Path):
+			m := childTeamsPath.FindStringSubmatch(r.URL.Path)
+			var children []*github.Team
+			for _, slug := range f.children[m[1]] {
+				children = append(children, &github.Team{Slug: github.String(slug)})
+			}
+			json.NewEncoder(w).Encode(children)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func newTestResolver(t *testing.T, api *fakeTeamsAPI) *Resolver {
+	t.Helper()
+	server := httptest.NewServer(api.handler())
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	base, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	client.BaseURL = base
+
+	return NewResolver(client, "acme", nil)
+}
+
+func TestIsMemberDirect(t *testing.T) {
+	r := newTestResolver(t, &fakeTeamsAPI{
+		memberships: map[string]string{"platform-infra/alice": "active"},
+	})
+
+	member, err := r.IsMember(context.Background(), "alice", []string{"platform-infra"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !member {
+		t.Error("expected alice to be a direct member of platform-infra")
+	}
+
+	member, err = r.IsMember(context.Background(), "bob", []string{"platform-infra"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if member {
+		t.Error("expected bob not to be a member")
+	}
+}
+
+func TestIsMemberViaDescendantTeam(t *testing.T) {
+	r := newTestResolver(t, &fakeTeamsAPI{
+		memberships: map[string]string{"platform-infra-oncall/carol": "active"},
+		children:    map[string][]string{"platform-infra": {"platform-infra-oncall"}},
+	})
+
+	member, err := r.IsMember(context.Background(), "carol", []string{"platform-infra"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !member {
+		t.Error("expected carol to pass via membership in a descendant team")
+	}
+}
+
+func TestIsMemberPropagatesChildTeamLookupError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case membershipPath.MatchString(r.URL.Path):
+			w.WriteHeader(http.StatusNotFound)
+		case childTeamsPath.MatchString(r.URL.Path):
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	base, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	client.BaseURL = base
+	r := NewResolver(client, "acme", nil)
+
+	_, err = r.IsMember(context.Background(), "erin", []string{"platform-infra"}, nil)
+	if err == nil {
+		t.Fatal("expected a transient child-team lookup failure to be propagated as an error, not treated as 'not a member'")
+	}
+}
+
+func TestIsMemberRestrictedByPermittedTeams(t *testing.T) {
+	r := newTestResolver(t, &fakeTeamsAPI{
+		memberships: map[string]string{"data-ingest/dave": "active"},
+	})
+
+	member, err := r.IsMember(context.Background(), "dave", []string{"data-ingest", "platform-infra"}, []string{"platform-infra"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if member {
+		t.Error("expected dave's membership in a non-permitted team not to count")
+	}
+}